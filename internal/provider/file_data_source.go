@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &FileDataSource{}
+
+func NewFileDataSource() datasource.DataSource {
+	return &FileDataSource{}
+}
+
+// FileDataSource defines the data source implementation.
+type FileDataSource struct {
+}
+
+// FileDataSourceModel describes the data source data model.
+type FileDataSourceModel struct {
+	ContentBase64 types.String         `tfsdk:"content_base64"`
+	Path          types.String         `tfsdk:"path"`
+	IncludeKeys   types.Bool           `tfsdk:"include_keys"`
+	Entries       []FileEntryDataModel `tfsdk:"entry"`
+	Id            types.String         `tfsdk:"id"`
+}
+
+type FileEntryDataModel struct {
+	Principal      types.String `tfsdk:"principal"`
+	Realm          types.String `tfsdk:"realm"`
+	KeyBase64      types.String `tfsdk:"key_base64"`
+	KeyVersion     types.Int64  `tfsdk:"key_version"`
+	EncryptionType types.String `tfsdk:"encryption_type"`
+	Timestamp      types.String `tfsdk:"timestamp"`
+}
+
+func (d *FileDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_file"
+}
+
+func (d *FileDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Parses an existing keytab file, allowing its entries to be inspected or merged with entries produced elsewhere, such as by `kadmin ktadd` or the `keytab_file` resource.",
+
+		Attributes: map[string]schema.Attribute{
+			"content_base64": schema.StringAttribute{
+				MarkdownDescription: "The base64 encoded keytab contents to parse. Mutually exclusive with `path`.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(
+						path.MatchRelative().AtName("content_base64"),
+						path.MatchRelative().AtName("path"),
+					),
+				},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "The path to a keytab file to parse. Mutually exclusive with `content_base64`.",
+				Optional:            true,
+			},
+			"include_keys": schema.BoolAttribute{
+				MarkdownDescription: "Whether to include the raw key material for each entry in `entry.key_base64`. Defaults to `false`, since keytab keys are sensitive and should not be persisted to state unless explicitly requested.",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The SHA256 hash of the binary keytab contents.",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"entry": schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"principal": schema.StringAttribute{
+							MarkdownDescription: "The name of the Kerberos principal to which the key belongs, not including the realm.",
+							Computed:            true,
+						},
+						"realm": schema.StringAttribute{
+							MarkdownDescription: "The realm to which the Kerberos principal belongs.",
+							Computed:            true,
+						},
+						"key_base64": schema.StringAttribute{
+							MarkdownDescription: "The base64 encoded key belonging to the Kerberos principal. The raw bytes are not guaranteed to be valid UTF-8 and can't be represented directly as a Terraform string, so they're exposed base64 encoded here, consistent with `keytab_random_key`'s `key_base64`. Only populated when `include_keys = true`.",
+							Computed:            true,
+							Sensitive:           true,
+						},
+						"key_version": schema.Int64Attribute{
+							MarkdownDescription: "The version number of the key.",
+							Computed:            true,
+						},
+						"encryption_type": schema.StringAttribute{
+							MarkdownDescription: "The encryption type of the key.",
+							Computed:            true,
+						},
+						"timestamp": schema.StringAttribute{
+							MarkdownDescription: "The creation timestamp for the Keytab entry in [RFC3339](https://datatracker.ietf.org/doc/html/rfc3339#section-5.8) format.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *FileDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+}
+
+func (d *FileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data FileDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var raw []byte
+
+	if data.Path.IsNull() {
+		var err error
+		raw, err = base64.StdEncoding.DecodeString(data.ContentBase64.ValueString())
+
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid content_base64", err.Error())
+			return
+		}
+	} else {
+		var err error
+		raw, err = os.ReadFile(data.Path.ValueString())
+
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to read keytab file", err.Error())
+			return
+		}
+	}
+
+	kt := keytab.New()
+
+	if err := kt.Unmarshal(raw); err != nil {
+		resp.Diagnostics.AddError("Unable to parse keytab", err.Error())
+		return
+	}
+
+	includeKeys := data.IncludeKeys.ValueBool()
+
+	entries := make([]FileEntryDataModel, 0, len(kt.Entries))
+
+	for _, entry := range kt.Entries {
+		name := etypeName(entry.Key.KeyType)
+
+		if name == "" {
+			resp.Diagnostics.AddError("Unknown encryption type", fmt.Sprintf("no known encryption type name for etype %d", entry.Key.KeyType))
+			return
+		}
+
+		entryModel := FileEntryDataModel{
+			Principal:      types.StringValue(strings.Join(entry.Principal.Components, "/")),
+			Realm:          types.StringValue(entry.Principal.Realm),
+			KeyVersion:     types.Int64Value(int64(entry.KVNO)),
+			EncryptionType: types.StringValue(name),
+			Timestamp:      types.StringValue(entry.Timestamp.Format("2006-01-02T15:04:05Z07:00")),
+		}
+
+		if includeKeys {
+			entryModel.KeyBase64 = types.StringValue(base64.StdEncoding.EncodeToString(entry.Key.KeyValue))
+		} else {
+			entryModel.KeyBase64 = types.StringNull()
+		}
+
+		entries = append(entries, entryModel)
+	}
+
+	data.Entries = entries
+	data.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString(raw))
+
+	sum := sha256.Sum256(raw)
+	data.Id = types.StringValue(fmt.Sprintf("%x", sum[:]))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
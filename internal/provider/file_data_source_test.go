@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/jcmturner/gokrb5/v8/iana/etypeID"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+)
+
+func TestAccFileDataSource(t *testing.T) {
+	kt := keytab.New()
+	if err := kt.AddEntry("principal", "realm.com", "key", time.Unix(0, 0), 0, etypeID.RC4_HMAC); err != nil {
+		t.Fatal(err.Error())
+		return
+	}
+
+	bytes, err := kt.Marshal()
+
+	if err != nil {
+		t.Fatal(err.Error())
+		return
+	}
+
+	contentBase64 := base64.StdEncoding.EncodeToString(bytes)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "keytab_file" "test" {
+  content_base64 = "` + contentBase64 + `"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.keytab_file.test", "id"),
+					resource.TestCheckResourceAttr("data.keytab_file.test", "entry.0.principal", "principal"),
+					resource.TestCheckResourceAttr("data.keytab_file.test", "entry.0.realm", "realm.com"),
+					resource.TestCheckResourceAttr("data.keytab_file.test", "entry.0.encryption_type", "arcfour-hmac"),
+					resource.TestCheckNoResourceAttr("data.keytab_file.test", "entry.0.key_base64"),
+				),
+			},
+		},
+	})
+}
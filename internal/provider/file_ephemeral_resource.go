@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jcmturner/gokrb5/v8/iana/etypeID"
+
+	"github.com/zanecodes/terraform-provider-keytab/internal/validators/timevalidator"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &FileEphemeralResource{}
+
+func NewFileEphemeralResource() ephemeral.EphemeralResource {
+	return &FileEphemeralResource{}
+}
+
+// FileEphemeralResource defines the ephemeral resource implementation.
+type FileEphemeralResource struct {
+}
+
+// FileEphemeralResourceModel describes the ephemeral resource data model.
+type FileEphemeralResourceModel struct {
+	Entries       []FileEntryModel `tfsdk:"entry"`
+	ContentBase64 types.String     `tfsdk:"content_base64"`
+	Id            types.String     `tfsdk:"id"`
+}
+
+func (r *FileEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_file"
+}
+
+func (r *FileEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	supportedEtypes := make([]string, 0)
+
+	for k := range etypeID.ETypesByName {
+		if etypeID.EtypeSupported(k) != 0 {
+			supportedEtypes = append(supportedEtypes, k)
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Builds a keytab in memory without ever persisting the key material or keytab bytes to Terraform state, for handing to a downstream ephemeral value such as a `kubernetes_secret` or `vault_kv_secret_v2` write-only field.",
+
+		Blocks: map[string]schema.Block{
+			"entry": schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"principal": schema.StringAttribute{
+							MarkdownDescription: "The name of the Kerberos principal to which the key belongs, not including the realm.",
+							Required:            true,
+						},
+						"realm": schema.StringAttribute{
+							MarkdownDescription: "The realm to which the Kerberos principal belongs.",
+							Required:            true,
+						},
+						"key": schema.StringAttribute{
+							MarkdownDescription: "The key belonging to the Kerberos principal. Mutually exclusive with `password`.",
+							Optional:            true,
+							Sensitive:           true,
+							Validators: []validator.String{
+								stringvalidator.ExactlyOneOf(
+									path.MatchRelative().AtParent().AtName("key"),
+									path.MatchRelative().AtParent().AtName("password"),
+								),
+							},
+						},
+						"password": schema.StringAttribute{
+							MarkdownDescription: "A password from which to derive the key via the RFC 3962 / RFC 8009 string-to-key algorithm for the chosen `encryption_type`. Mutually exclusive with `key`. Not supported for `des3-cbc-sha1-kd`.",
+							Optional:            true,
+							Sensitive:           true,
+						},
+						"salt": schema.StringAttribute{
+							MarkdownDescription: "The salt to use when deriving the key from `password`. Defaults to the realm followed by the principal, with no separator, matching MIT's default salt.",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("key")),
+								stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("password")),
+							},
+						},
+						"iterations": schema.Int64Attribute{
+							MarkdownDescription: "The number of iterations to use when deriving the key from `password`.",
+							Optional:            true,
+							Validators: []validator.Int64{
+								int64validator.ConflictsWith(path.MatchRelative().AtParent().AtName("key")),
+								int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("password")),
+							},
+						},
+						"key_version": schema.Int64Attribute{
+							MarkdownDescription: "The version number of the key.",
+							Required:            true,
+							Validators: []validator.Int64{
+								int64validator.Between(0, math.MaxUint8),
+							},
+						},
+						"encryption_type": schema.StringAttribute{
+							MarkdownDescription: "The encryption type to use for the key. Must be one of: `aes128-cts-hmac-sha1-96`/`aes128-cts`/`aes128-sha1`, `aes256-cts-hmac-sha1-96`/`aes256-cts`/`aes256-sha1`, `aes128-cts-hmac-sha256-128`/`aes128-sha2`, `aes256-cts-hmac-sha384-192`/`aes256-sha2`, `des3-cbc-sha1-kd`, or `arcfour-hmac`/`rc4-hmac`/`arcfour-hmac-md5`.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(supportedEtypes...),
+							},
+						},
+						"timestamp": schema.StringAttribute{
+							MarkdownDescription: "The creation timestamp for the Keytab entry in [RFC3339](https://datatracker.ietf.org/doc/html/rfc3339#section-5.8) format. Defaults to the current time, but may be set to a specific time for better reproducibility.",
+							Optional:            true,
+							Computed:            true,
+							Validators: []validator.String{
+								timevalidator.IsRFC3339Time(),
+							},
+						},
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The SHA256 hash of `principal`, `realm`, `key_version`, and `encryption_type`.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+
+		Attributes: map[string]schema.Attribute{
+			"content_base64": schema.StringAttribute{
+				MarkdownDescription: "The base64 encoded keytab contents.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The SHA256 hash of the binary keytab contents.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *FileEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+}
+
+func (r *FileEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data FileEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	kt, err := buildKeytab(data.Entries, time.Now())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid keytab entry", err.Error())
+		return
+	}
+
+	bytes, err := kt.Marshal()
+
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to generate keytab", err.Error())
+		return
+	}
+
+	data.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString(bytes))
+
+	sum := sha256.Sum256(bytes)
+	data.Id = types.StringValue(fmt.Sprintf("%x", sum[:]))
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (r *FileEphemeralResource) Renew(ctx context.Context, req ephemeral.RenewRequest, resp *ephemeral.RenewResponse) {
+}
+
+func (r *FileEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+}
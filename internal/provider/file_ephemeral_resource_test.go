@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccFileEphemeralResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"echo": echoprovider.NewProviderServer(),
+		},
+		ProtoV6ProviderFactoriesForEphemeral: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+ephemeral "keytab_file" "test" {
+  entry {
+    principal = "principal"
+    realm = "realm.com"
+    key = "key"
+    key_version = 0
+    encryption_type = "rc4-hmac"
+    timestamp = "1970-01-01T00:00:00Z"
+  }
+}
+
+provider "echo" {
+  data = ephemeral.keytab_file.test
+}
+
+resource "echo" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("echo.test", "data.id"),
+					resource.TestCheckResourceAttrSet("echo.test", "data.content_base64"),
+				),
+			},
+		},
+	})
+}
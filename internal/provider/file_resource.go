@@ -10,21 +10,22 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/jcmturner/gokrb5/v8/iana/etypeID"
 	"github.com/jcmturner/gokrb5/v8/keytab"
+	krb5types "github.com/jcmturner/gokrb5/v8/types"
 
 	"github.com/zanecodes/terraform-provider-keytab/internal/validators/timevalidator"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &FileResource{}
+var _ resource.ResourceWithModifyPlan = &FileResource{}
 
 func NewFileResource() resource.Resource {
 	return &FileResource{}
@@ -45,9 +46,13 @@ type FileEntryModel struct {
 	Principal      types.String `tfsdk:"principal"`
 	Realm          types.String `tfsdk:"realm"`
 	Key            types.String `tfsdk:"key"`
+	Password       types.String `tfsdk:"password"`
+	Salt           types.String `tfsdk:"salt"`
+	Iterations     types.Int64  `tfsdk:"iterations"`
 	KeyVersion     types.Int64  `tfsdk:"key_version"`
 	EncryptionType types.String `tfsdk:"encryption_type"`
 	Timestamp      types.String `tfsdk:"timestamp"`
+	Id             types.String `tfsdk:"id"`
 }
 
 func (r *FileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -80,10 +85,37 @@ func (r *FileResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 							Required:            true,
 						},
 						"key": schema.StringAttribute{
-							MarkdownDescription: "The key belonging to the Kerberos principal.",
-							Required:            true,
+							MarkdownDescription: "The key belonging to the Kerberos principal. Mutually exclusive with `password`.",
+							Optional:            true,
+							Sensitive:           true,
+							Validators: []validator.String{
+								stringvalidator.ExactlyOneOf(
+									path.MatchRelative().AtParent().AtName("key"),
+									path.MatchRelative().AtParent().AtName("password"),
+								),
+							},
+						},
+						"password": schema.StringAttribute{
+							MarkdownDescription: "A password from which to derive the key via the RFC 3962 / RFC 8009 string-to-key algorithm for the chosen `encryption_type`. Mutually exclusive with `key`. Not supported for `des3-cbc-sha1-kd`.",
+							Optional:            true,
 							Sensitive:           true,
 						},
+						"salt": schema.StringAttribute{
+							MarkdownDescription: "The salt to use when deriving the key from `password`. Defaults to the realm followed by the principal, with no separator, matching MIT's default salt.",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("key")),
+								stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("password")),
+							},
+						},
+						"iterations": schema.Int64Attribute{
+							MarkdownDescription: "The number of iterations to use when deriving the key from `password`. Defaults to 4096 for `aes128-cts-hmac-sha1-96`/`aes256-cts-hmac-sha1-96`, or 32768 for `aes128-cts-hmac-sha256-128`/`aes256-cts-hmac-sha384-192`.",
+							Optional:            true,
+							Validators: []validator.Int64{
+								int64validator.ConflictsWith(path.MatchRelative().AtParent().AtName("key")),
+								int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("password")),
+							},
+						},
 						"key_version": schema.Int64Attribute{
 							MarkdownDescription: "The version number of the key.",
 							Required:            true,
@@ -106,10 +138,10 @@ func (r *FileResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 								timevalidator.IsRFC3339Time(),
 							},
 						},
-					},
-
-					PlanModifiers: []planmodifier.Object{
-						objectplanmodifier.RequiresReplaceIfConfigured(),
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The SHA256 hash of `principal`, `realm`, `key_version`, and `encryption_type`. Gives each entry a stable identity so Terraform can report added, removed, and changed entries in the plan diff instead of list-index churn.",
+							Computed:            true,
+						},
 					},
 				},
 			},
@@ -132,43 +164,101 @@ func (r *FileResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 func (r *FileResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 }
 
-func (r *FileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data *FileResourceModel
+// ModifyPlan realigns the planned `entry` list against the prior state's
+// order by each entry's identity (principal, realm, key_version,
+// encryption_type) before Terraform core computes the diff. ListNestedBlock
+// is diffed positionally, so without this an insert or removal in the
+// middle of `entry` would show every subsequent entry as changed; aligning
+// unchanged entries back to their prior index lets Terraform report only
+// the entries that were actually added, removed, or changed.
+func (r *FileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
 
-	now := time.Now()
+	var state, plan FileResourceModel
 
-	// Read Terraform plan data into the model
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	keytab := keytab.New()
+	reordered := reorderEntries(state.Entries, plan.Entries)
 
-	for i, entry := range data.Entries {
-		var timestamp time.Time
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("entry"), reordered)...)
+}
 
-		if entry.Timestamp.IsUnknown() {
-			timestamp = now
-			data.Entries[i].Timestamp = types.StringValue(timestamp.Format(time.RFC3339))
-		} else {
-			var err error
-			timestamp, err = time.Parse(time.RFC3339, entry.Timestamp.ValueString())
+// reorderEntries returns planEntries reordered so that any entry whose
+// identity (see entryKey) also appears in stateEntries keeps that entry's
+// prior position; entries with no match in stateEntries (new entries) are
+// appended at the end in their original relative order.
+//
+// Entries are queued per identity rather than keyed by a single map slot, so
+// that entries sharing an identity (a copy-paste mistake, or a for_each bug
+// producing a collision) are matched up one-for-one with their counterparts
+// in stateEntries instead of being silently collapsed and dropped from the
+// plan; every planEntries element always appears exactly once in the result.
+func reorderEntries(stateEntries, planEntries []FileEntryModel) []FileEntryModel {
+	byKey := make(map[string][]FileEntryModel, len(planEntries))
+
+	for _, e := range planEntries {
+		k := entryKey(e)
+		byKey[k] = append(byKey[k], e)
+	}
 
-			if err != nil {
-				resp.Diagnostics.AddError("Invalid timestamp", err.Error())
-				return
-			}
+	reordered := make([]FileEntryModel, 0, len(planEntries))
+
+	for _, e := range stateEntries {
+		k := entryKey(e)
+
+		if queue := byKey[k]; len(queue) > 0 {
+			reordered = append(reordered, queue[0])
+			byKey[k] = queue[1:]
 		}
+	}
 
-		if err := keytab.AddEntry(entry.Principal.ValueString(), entry.Realm.ValueString(), entry.Key.ValueString(), timestamp, uint8(entry.KeyVersion.ValueInt64()), etypeID.EtypeSupported(entry.EncryptionType.ValueString())); err != nil {
-			resp.Diagnostics.AddError("Invalid keytab entry", err.Error())
-			return
+	for _, e := range planEntries {
+		k := entryKey(e)
+
+		if queue := byKey[k]; len(queue) > 0 {
+			reordered = append(reordered, queue[0])
+			byKey[k] = queue[1:]
 		}
 	}
 
-	bytes, err := keytab.Marshal()
+	return reordered
+}
+
+// entryKey returns the identity reorderEntries aligns on: the same fields
+// (principal, realm, key_version, encryption_type) used to compute the
+// entry's `id`, but taken directly from config rather than hashed, since
+// ModifyPlan runs before buildKeytab populates `id`.
+func entryKey(e FileEntryModel) string {
+	return fmt.Sprintf("%s|%s|%d|%s", e.Principal.ValueString(), e.Realm.ValueString(), e.KeyVersion.ValueInt64(), e.EncryptionType.ValueString())
+}
+
+func (r *FileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *FileResourceModel
+
+	now := time.Now()
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	kt, err := buildKeytab(data.Entries, now)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid keytab entry", err.Error())
+		return
+	}
+
+	bytes, err := kt.Marshal()
 
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to generate keytab", err.Error())
@@ -205,6 +295,8 @@ func (r *FileResource) Read(ctx context.Context, req resource.ReadRequest, resp
 func (r *FileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data *FileResourceModel
 
+	now := time.Now()
+
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
@@ -212,6 +304,27 @@ func (r *FileResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	kt, err := buildKeytab(data.Entries, now)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid keytab entry", err.Error())
+		return
+	}
+
+	bytes, err := kt.Marshal()
+
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to generate keytab", err.Error())
+		return
+	}
+
+	data.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString(bytes))
+
+	sum := sha256.Sum256(bytes)
+	data.Id = types.StringValue(fmt.Sprintf("%x", sum[:]))
+
+	tflog.Trace(ctx, "updated a resource")
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -226,3 +339,69 @@ func (r *FileResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 }
+
+// buildKeytab derives the key for each entry (from either `key` or
+// `password`) and assembles the resulting keytab. Entries with an unknown or
+// unset timestamp are stamped with now and have their model updated in place.
+//
+// keytab.Entry and its Principal are unexported, so there's no way to
+// construct an entry directly. AddEntry can't be used to install an
+// already-known key either: it treats its `password` argument literally as a
+// password and runs it through the string-to-key KDF itself, which would
+// derive the wrong key for both a user-supplied `key` and an already-derived
+// `password` key. Instead, AddEntry is called with a placeholder to obtain a
+// correctly-populated principal/timestamp/kvno slot, and the slot's
+// `Key` field - an exported types.EncryptionKey, even though the entry and
+// principal types that hold it are unexported - is then overwritten with the
+// real key bytes directly.
+func buildKeytab(entries []FileEntryModel, now time.Time) (*keytab.Keytab, error) {
+	kt := keytab.New()
+
+	for i, entry := range entries {
+		var timestamp time.Time
+
+		if entry.Timestamp.IsUnknown() || entry.Timestamp.IsNull() {
+			timestamp = now
+			entries[i].Timestamp = types.StringValue(timestamp.Format(time.RFC3339))
+		} else {
+			var err error
+			timestamp, err = time.Parse(time.RFC3339, entry.Timestamp.ValueString())
+
+			if err != nil {
+				return nil, fmt.Errorf("invalid timestamp: %w", err)
+			}
+		}
+
+		etype := etypeID.EtypeSupported(entry.EncryptionType.ValueString())
+
+		keyValue := []byte(entry.Key.ValueString())
+
+		if !entry.Password.IsNull() {
+			var err error
+			keyValue, err = deriveKey(etype, entry.Principal.ValueString(), entry.Realm.ValueString(), entry.Password.ValueString(), entry.Salt.ValueString(), entry.Iterations.ValueInt64())
+
+			if err != nil {
+				return nil, fmt.Errorf("unable to derive key from password: %w", err)
+			}
+		}
+
+		kvno := uint8(entry.KeyVersion.ValueInt64())
+
+		if err := kt.AddEntry(entry.Principal.ValueString(), entry.Realm.ValueString(), "placeholder", timestamp, kvno, etype); err != nil {
+			return nil, fmt.Errorf("invalid keytab entry: %w", err)
+		}
+
+		kt.Entries[len(kt.Entries)-1].Key = krb5types.EncryptionKey{KeyType: etype, KeyValue: keyValue}
+
+		entries[i].Id = types.StringValue(entryId(entry.Principal.ValueString(), entry.Realm.ValueString(), kvno, etype))
+	}
+
+	return kt, nil
+}
+
+// entryId computes the stable per-entry identity used to give Terraform a
+// clean plan diff (added/removed/changed) instead of list-index churn.
+func entryId(principal, realm string, kvno uint8, etype int32) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%d|%d", principal, realm, kvno, etype)))
+	return fmt.Sprintf("%x", sum[:])
+}
@@ -6,26 +6,41 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/jcmturner/gokrb5/v8/iana/etypeID"
 	"github.com/jcmturner/gokrb5/v8/keytab"
+	krb5types "github.com/jcmturner/gokrb5/v8/types"
 )
 
+// addRawKeyEntry adds an entry whose key is the literal bytes of key,
+// mirroring buildKeytab's handling of the `key` attribute: unlike
+// keytab.Keytab.AddEntry, it does not run key through a string-to-key KDF.
+func addRawKeyEntry(kt *keytab.Keytab, principal, realm, key string, ts time.Time, kvno uint8, etype int32) error {
+	if err := kt.AddEntry(principal, realm, "placeholder", ts, kvno, etype); err != nil {
+		return err
+	}
+
+	kt.Entries[len(kt.Entries)-1].Key = krb5types.EncryptionKey{KeyType: etype, KeyValue: []byte(key)}
+
+	return nil
+}
+
 func TestAccFileResource(t *testing.T) {
 	first_keytab := keytab.New()
 
 	second_keytab := keytab.New()
-	if err := second_keytab.AddEntry("principal", "realm.com", "key", time.Unix(0, 0), 0, etypeID.RC4_HMAC); err != nil {
+	if err := addRawKeyEntry(second_keytab, "principal", "realm.com", "key", time.Unix(0, 0), 0, etypeID.RC4_HMAC); err != nil {
 		t.Fatal(err.Error())
 		return
 	}
 
 	third_keytab := keytab.New()
-	if err := third_keytab.AddEntry("principal", "realm.com", "key", time.Unix(0, 0), 0, etypeID.RC4_HMAC); err != nil {
+	if err := addRawKeyEntry(third_keytab, "principal", "realm.com", "key", time.Unix(0, 0), 0, etypeID.RC4_HMAC); err != nil {
 		t.Fatal(err.Error())
 		return
 	}
-	if err := third_keytab.AddEntry("principal two", "realm-two.com", "key two", time.Unix(1, 0), 1, etypeID.AES128_CTS_HMAC_SHA1_96); err != nil {
+	if err := addRawKeyEntry(third_keytab, "principal two", "realm-two.com", "key two", time.Unix(1, 0), 1, etypeID.AES128_CTS_HMAC_SHA1_96); err != nil {
 		t.Fatal(err.Error())
 		return
 	}
@@ -48,7 +63,7 @@ resource "keytab_file" "test" {
 }
 `,
 				PreConfig: func() {
-					if err := first_keytab.AddEntry("principal", "realm.com", "key", time.Now().Truncate(time.Second), 0, etypeID.RC4_HMAC); err != nil {
+					if err := addRawKeyEntry(first_keytab, "principal", "realm.com", "key", time.Now().Truncate(time.Second), 0, etypeID.RC4_HMAC); err != nil {
 						t.Fatal(err.Error())
 						return
 					}
@@ -106,6 +121,33 @@ resource "keytab_file" "test" {
 	})
 }
 
+// TestReorderEntries verifies that inserting an entry in the middle of the
+// list doesn't disturb the position of entries that already existed in
+// state, which is what lets Terraform report the insert as an add instead
+// of churning every subsequent entry's index.
+func TestReorderEntries(t *testing.T) {
+	a := FileEntryModel{Principal: types.StringValue("a"), Realm: types.StringValue("realm.com"), KeyVersion: types.Int64Value(0), EncryptionType: types.StringValue("rc4-hmac")}
+	b := FileEntryModel{Principal: types.StringValue("b"), Realm: types.StringValue("realm.com"), KeyVersion: types.Int64Value(0), EncryptionType: types.StringValue("rc4-hmac")}
+	c := FileEntryModel{Principal: types.StringValue("c"), Realm: types.StringValue("realm.com"), KeyVersion: types.Int64Value(0), EncryptionType: types.StringValue("rc4-hmac")}
+
+	state := []FileEntryModel{a, c}
+	plan := []FileEntryModel{a, b, c}
+
+	got := reorderEntries(state, plan)
+
+	want := []string{"a", "c", "b"}
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %d entries, got %d", len(want), len(got))
+	}
+
+	for i, principal := range want {
+		if got[i].Principal.ValueString() != principal {
+			t.Fatalf("expected entry %d to have principal %q, got %q", i, principal, got[i].Principal.ValueString())
+		}
+	}
+}
+
 func testAccCheckKeytabContent(_ *testing.T, expected *keytab.Keytab) resource.CheckResourceAttrWithFunc {
 	return func(actual_value string) error {
 		expected_bytes, err := expected.Marshal()
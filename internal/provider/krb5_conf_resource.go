@@ -0,0 +1,328 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jcmturner/gokrb5/v8/iana/etypeID"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &Krb5ConfResource{}
+
+func NewKrb5ConfResource() resource.Resource {
+	return &Krb5ConfResource{}
+}
+
+// Krb5ConfResource defines the resource implementation.
+type Krb5ConfResource struct {
+}
+
+// Krb5ConfResourceModel describes the resource data model.
+type Krb5ConfResourceModel struct {
+	DefaultRealm       types.String                       `tfsdk:"default_realm"`
+	Realms             []Krb5ConfRealmModel               `tfsdk:"realm"`
+	DomainRealm        map[string]types.String            `tfsdk:"domain_realm"`
+	CaPaths            map[string]map[string]types.String `tfsdk:"capaths"`
+	DefaultTktEnctypes []types.String                     `tfsdk:"default_tkt_enctypes"`
+	DefaultTgsEnctypes []types.String                     `tfsdk:"default_tgs_enctypes"`
+	PermittedEnctypes  []types.String                     `tfsdk:"permitted_enctypes"`
+	DNSLookupKDC       types.Bool                         `tfsdk:"dns_lookup_kdc"`
+	DNSLookupRealm     types.Bool                         `tfsdk:"dns_lookup_realm"`
+	UDPPreferenceLimit types.Int64                        `tfsdk:"udp_preference_limit"`
+	Content            types.String                       `tfsdk:"content"`
+	ContentBase64      types.String                       `tfsdk:"content_base64"`
+	Id                 types.String                       `tfsdk:"id"`
+}
+
+type Krb5ConfRealmModel struct {
+	Name        types.String   `tfsdk:"name"`
+	KDC         []types.String `tfsdk:"kdc"`
+	AdminServer types.String   `tfsdk:"admin_server"`
+	MasterKDC   types.String   `tfsdk:"master_kdc"`
+}
+
+func (r *Krb5ConfResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_krb5_conf"
+}
+
+func (r *Krb5ConfResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	supportedEtypes := make([]string, 0)
+
+	for k := range etypeID.ETypesByName {
+		if etypeID.EtypeSupported(k) != 0 {
+			supportedEtypes = append(supportedEtypes, k)
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Generates a krb5.conf suitable for pairing with a keytab produced by `keytab_file`, for use by downstream Kerberos clients (JVM, HDFS/DataSync, etc.).",
+
+		Attributes: map[string]schema.Attribute{
+			"default_realm": schema.StringAttribute{
+				MarkdownDescription: "The default Kerberos realm, written to `[libdefaults]`.",
+				Required:            true,
+			},
+			"default_tkt_enctypes": schema.ListAttribute{
+				MarkdownDescription: "The `default_tkt_enctypes` list in `[libdefaults]`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(supportedEtypes...)),
+				},
+			},
+			"default_tgs_enctypes": schema.ListAttribute{
+				MarkdownDescription: "The `default_tgs_enctypes` list in `[libdefaults]`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(supportedEtypes...)),
+				},
+			},
+			"permitted_enctypes": schema.ListAttribute{
+				MarkdownDescription: "The `permitted_enctypes` list in `[libdefaults]`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(supportedEtypes...)),
+				},
+			},
+			"dns_lookup_kdc": schema.BoolAttribute{
+				MarkdownDescription: "The `dns_lookup_kdc` setting in `[libdefaults]`.",
+				Optional:            true,
+			},
+			"dns_lookup_realm": schema.BoolAttribute{
+				MarkdownDescription: "The `dns_lookup_realm` setting in `[libdefaults]`.",
+				Optional:            true,
+			},
+			"udp_preference_limit": schema.Int64Attribute{
+				MarkdownDescription: "The `udp_preference_limit` setting in `[libdefaults]`.",
+				Optional:            true,
+			},
+			"domain_realm": schema.MapAttribute{
+				MarkdownDescription: "A map of domain or hostname to realm, written to `[domain_realm]`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"capaths": schema.MapAttribute{
+				MarkdownDescription: "A map of client realm to a map of server realm to intermediate realm, written to `[capaths]` to describe the authentication path between realms that aren't directly cross-realm trusted.",
+				Optional:            true,
+				ElementType:         types.MapType{ElemType: types.StringType},
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "The generated krb5.conf contents.",
+				Computed:            true,
+			},
+			"content_base64": schema.StringAttribute{
+				MarkdownDescription: "The base64 encoded krb5.conf contents.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The SHA256 hash of the krb5.conf contents.",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"realm": schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the realm.",
+							Required:            true,
+						},
+						"kdc": schema.ListAttribute{
+							MarkdownDescription: "The KDCs for the realm.",
+							Required:            true,
+							ElementType:         types.StringType,
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+						},
+						"admin_server": schema.StringAttribute{
+							MarkdownDescription: "The admin server for the realm.",
+							Optional:            true,
+						},
+						"master_kdc": schema.StringAttribute{
+							MarkdownDescription: "The master KDC for the realm.",
+							Optional:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *Krb5ConfResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+}
+
+func (r *Krb5ConfResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *Krb5ConfResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	content := renderKrb5Conf(data)
+
+	data.Content = types.StringValue(content)
+	data.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString([]byte(content)))
+
+	sum := sha256.Sum256([]byte(content))
+	data.Id = types.StringValue(fmt.Sprintf("%x", sum[:]))
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *Krb5ConfResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *Krb5ConfResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *Krb5ConfResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *Krb5ConfResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	content := renderKrb5Conf(data)
+
+	data.Content = types.StringValue(content)
+	data.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString([]byte(content)))
+
+	sum := sha256.Sum256([]byte(content))
+	data.Id = types.StringValue(fmt.Sprintf("%x", sum[:]))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *Krb5ConfResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *Krb5ConfResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// renderKrb5Conf writes out an MIT krb5.conf with the [libdefaults],
+// [realms], [domain_realm], and [capaths] sections in the grammar documented
+// at https://web.mit.edu/kerberos/krb5-latest/doc/admin/conf_files/krb5_conf.html.
+func renderKrb5Conf(data *Krb5ConfResourceModel) string {
+	var b strings.Builder
+
+	b.WriteString("[libdefaults]\n")
+	fmt.Fprintf(&b, "\tdefault_realm = %s\n", data.DefaultRealm.ValueString())
+
+	if len(data.DefaultTktEnctypes) > 0 {
+		fmt.Fprintf(&b, "\tdefault_tkt_enctypes = %s\n", joinStringValues(data.DefaultTktEnctypes))
+	}
+	if len(data.DefaultTgsEnctypes) > 0 {
+		fmt.Fprintf(&b, "\tdefault_tgs_enctypes = %s\n", joinStringValues(data.DefaultTgsEnctypes))
+	}
+	if len(data.PermittedEnctypes) > 0 {
+		fmt.Fprintf(&b, "\tpermitted_enctypes = %s\n", joinStringValues(data.PermittedEnctypes))
+	}
+	if !data.DNSLookupKDC.IsNull() {
+		fmt.Fprintf(&b, "\tdns_lookup_kdc = %t\n", data.DNSLookupKDC.ValueBool())
+	}
+	if !data.DNSLookupRealm.IsNull() {
+		fmt.Fprintf(&b, "\tdns_lookup_realm = %t\n", data.DNSLookupRealm.ValueBool())
+	}
+	if !data.UDPPreferenceLimit.IsNull() {
+		fmt.Fprintf(&b, "\tudp_preference_limit = %d\n", data.UDPPreferenceLimit.ValueInt64())
+	}
+
+	b.WriteString("\n[realms]\n")
+	for _, realm := range data.Realms {
+		fmt.Fprintf(&b, "\t%s = {\n", realm.Name.ValueString())
+		for _, kdc := range realm.KDC {
+			fmt.Fprintf(&b, "\t\tkdc = %s\n", kdc.ValueString())
+		}
+		if !realm.AdminServer.IsNull() {
+			fmt.Fprintf(&b, "\t\tadmin_server = %s\n", realm.AdminServer.ValueString())
+		}
+		if !realm.MasterKDC.IsNull() {
+			fmt.Fprintf(&b, "\t\tmaster_kdc = %s\n", realm.MasterKDC.ValueString())
+		}
+		b.WriteString("\t}\n")
+	}
+
+	if len(data.DomainRealm) > 0 {
+		b.WriteString("\n[domain_realm]\n")
+
+		domains := make([]string, 0, len(data.DomainRealm))
+		for domain := range data.DomainRealm {
+			domains = append(domains, domain)
+		}
+		sort.Strings(domains)
+
+		for _, domain := range domains {
+			fmt.Fprintf(&b, "\t%s = %s\n", domain, data.DomainRealm[domain].ValueString())
+		}
+	}
+
+	if len(data.CaPaths) > 0 {
+		b.WriteString("\n[capaths]\n")
+
+		clientRealms := make([]string, 0, len(data.CaPaths))
+		for clientRealm := range data.CaPaths {
+			clientRealms = append(clientRealms, clientRealm)
+		}
+		sort.Strings(clientRealms)
+
+		for _, clientRealm := range clientRealms {
+			fmt.Fprintf(&b, "\t%s = {\n", clientRealm)
+
+			hops := data.CaPaths[clientRealm]
+			serverRealms := make([]string, 0, len(hops))
+			for serverRealm := range hops {
+				serverRealms = append(serverRealms, serverRealm)
+			}
+			sort.Strings(serverRealms)
+
+			for _, serverRealm := range serverRealms {
+				fmt.Fprintf(&b, "\t\t%s = %s\n", serverRealm, hops[serverRealm].ValueString())
+			}
+
+			b.WriteString("\t}\n")
+		}
+	}
+
+	return b.String()
+}
+
+func joinStringValues(values []types.String) string {
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		parts = append(parts, v.ValueString())
+	}
+	return strings.Join(parts, ",")
+}
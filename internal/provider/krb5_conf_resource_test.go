@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func krb5ConfContains(actual string, want ...string) error {
+	for _, w := range want {
+		if !strings.Contains(actual, w) {
+			return fmt.Errorf("expected krb5.conf content to contain %q, got:\n%s", w, actual)
+		}
+	}
+	return nil
+}
+
+func TestAccKrb5ConfResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "keytab_krb5_conf" "test" {
+  default_realm = "REALM.COM"
+
+  realm {
+    name = "REALM.COM"
+    kdc  = ["kdc.realm.com"]
+  }
+
+  domain_realm = {
+    ".realm.com" = "REALM.COM"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("keytab_krb5_conf.test", "id"),
+					resource.TestCheckResourceAttrSet("keytab_krb5_conf.test", "content"),
+					resource.TestCheckResourceAttrWith("keytab_krb5_conf.test", "content", func(actual string) error {
+						return krb5ConfContains(actual,
+							"[libdefaults]",
+							"default_realm = REALM.COM",
+							"[realms]",
+							"REALM.COM = {",
+							"kdc = kdc.realm.com",
+							"[domain_realm]",
+							".realm.com = REALM.COM",
+						)
+					}),
+				),
+			},
+			{
+				Config: `
+resource "keytab_krb5_conf" "test" {
+  default_realm = "REALM.COM"
+
+  realm {
+    name = "REALM.COM"
+    kdc  = ["kdc.realm.com"]
+  }
+
+  domain_realm = {
+    ".realm.com" = "REALM.COM"
+  }
+
+  capaths = {
+    "CLIENT.REALM.COM" = {
+      "REALM.COM" = "INTERMEDIATE.REALM.COM"
+    }
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("keytab_krb5_conf.test", "content", func(actual string) error {
+						return krb5ConfContains(actual,
+							"[capaths]",
+							"CLIENT.REALM.COM = {",
+							"REALM.COM = INTERMEDIATE.REALM.COM",
+						)
+					}),
+				),
+			},
+		},
+	})
+}
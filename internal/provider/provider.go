@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -13,6 +14,7 @@ import (
 
 // Ensure KeytabProvider satisfies various provider interfaces.
 var _ provider.Provider = &KeytabProvider{}
+var _ provider.ProviderWithEphemeralResources = &KeytabProvider{}
 
 // KeytabProvider defines the provider implementation.
 type KeytabProvider struct {
@@ -63,12 +65,22 @@ func (p *KeytabProvider) Configure(ctx context.Context, req provider.ConfigureRe
 
 func (p *KeytabProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
-		NewExampleResource,
+		NewFileResource,
+		NewKrb5ConfResource,
+		NewRandomKeyResource,
 	}
 }
 
 func (p *KeytabProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewFileDataSource,
+	}
+}
+
+func (p *KeytabProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewFileEphemeralResource,
+	}
 }
 
 func New(version string) func() provider.Provider {
@@ -0,0 +1,21 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// testAccProtoV6ProviderFactories are used to instantiate a provider during
+// acceptance testing. The factory function is called for each Terraform CLI
+// command executed to create a provider server to which the CLI can
+// reattach.
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"keytab": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+// testAccPreCheck validates the necessary test API keys exist in the
+// testing environment, or performs any other pre-test infrastructure check.
+func testAccPreCheck(t *testing.T) {
+}
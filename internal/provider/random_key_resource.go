@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jcmturner/gokrb5/v8/iana/etypeID"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RandomKeyResource{}
+
+func NewRandomKeyResource() resource.Resource {
+	return &RandomKeyResource{}
+}
+
+// RandomKeyResource defines the resource implementation.
+type RandomKeyResource struct {
+}
+
+// RandomKeyResourceModel describes the resource data model.
+type RandomKeyResourceModel struct {
+	EncryptionType types.String            `tfsdk:"encryption_type"`
+	Keepers        map[string]types.String `tfsdk:"keepers"`
+	KeyBase64      types.String            `tfsdk:"key_base64"`
+	Id             types.String            `tfsdk:"id"`
+}
+
+func (r *RandomKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_random_key"
+}
+
+func (r *RandomKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	supportedEtypes := make([]string, 0)
+
+	for k := range etypeID.ETypesByName {
+		if etypeID.EtypeSupported(k) != 0 {
+			supportedEtypes = append(supportedEtypes, k)
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Generates a cryptographically random key of the correct byte length for a given keytab `encryption_type`, without the caller having to bring their own key material. The key is only exposed as `key_base64`, since the raw bytes are not guaranteed to be valid UTF-8 and can't be represented directly as a Terraform string. `keytab_file`'s entry `key` takes a string and uses its bytes as the key, so a generated key whose bytes are not valid UTF-8 cannot be passed to it.",
+
+		Attributes: map[string]schema.Attribute{
+			"encryption_type": schema.StringAttribute{
+				MarkdownDescription: "The encryption type to generate a key for. See `keytab_file`'s `entry.encryption_type` for the list of supported values.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(supportedEtypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"keepers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary map of values that, when changed, forces generation of a new key. See the [random provider documentation](https://registry.terraform.io/providers/hashicorp/random/latest/docs) for more on this pattern.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"key_base64": schema.StringAttribute{
+				MarkdownDescription: "The base64 encoded generated key.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The SHA256 hash of the generated key.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *RandomKeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+}
+
+func (r *RandomKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *RandomKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	etype := etypeID.EtypeSupported(data.EncryptionType.ValueString())
+
+	key, err := randomKey(etype)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to generate random key", err.Error())
+		return
+	}
+
+	data.KeyBase64 = types.StringValue(base64.StdEncoding.EncodeToString(key))
+	data.Id = types.StringValue(keySha256(key))
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RandomKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *RandomKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RandomKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *RandomKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RandomKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *RandomKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// randomKeyLength returns the key length in bytes required for etype, or an
+// error if etype isn't one this resource knows how to size a key for.
+func randomKeyLength(etype int32) (int, error) {
+	switch etype {
+	case etypeID.AES128_CTS_HMAC_SHA1_96, etypeID.AES128_CTS_HMAC_SHA256_128:
+		return 16, nil
+	case etypeID.AES256_CTS_HMAC_SHA1_96, etypeID.AES256_CTS_HMAC_SHA384_192:
+		return 32, nil
+	case etypeID.RC4_HMAC:
+		return 16, nil
+	case etypeID.DES3_CBC_SHA1_KD:
+		return 24, nil
+	default:
+		return 0, fmt.Errorf("unsupported encryption type %d", etype)
+	}
+}
+
+// randomKey generates a cryptographically random key of the correct length
+// for etype. For des3-cbc-sha1-kd, it applies the DES parity bit fixup
+// gokrb5 expects of each key byte.
+func randomKey(etype int32) ([]byte, error) {
+	length, err := randomKeyLength(etype)
+
+	if err != nil {
+		return nil, err
+	}
+
+	key := make([]byte, length)
+
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("unable to read random bytes: %w", err)
+	}
+
+	if etype == etypeID.DES3_CBC_SHA1_KD {
+		fixDESParity(key)
+	}
+
+	return key, nil
+}
+
+// fixDESParity sets the low bit of each byte so that it has odd parity, as
+// required of each 8-byte DES subkey within a DES3 key.
+func fixDESParity(key []byte) {
+	for i, b := range key {
+		var ones int
+
+		for bit := 1; bit < 8; bit++ {
+			if b&(1<<uint(bit)) != 0 {
+				ones++
+			}
+		}
+
+		if ones%2 == 0 {
+			key[i] = b | 1
+		} else {
+			key[i] = b &^ 1
+		}
+	}
+}
+
+func keySha256(key []byte) string {
+	sum := sha256.Sum256(key)
+	return fmt.Sprintf("%x", sum[:])
+}
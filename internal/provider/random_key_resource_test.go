@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccRandomKeyResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "keytab_random_key" "test" {
+  encryption_type = "aes256-cts-hmac-sha1-96"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("keytab_random_key.test", "id"),
+					resource.TestCheckResourceAttrSet("keytab_random_key.test", "key_base64"),
+				),
+			},
+		},
+	})
+}
+
+func TestFixDESParity(t *testing.T) {
+	key := []byte{0x00, 0xff, 0x01, 0xfe}
+	fixDESParity(key)
+
+	for _, b := range key {
+		var ones int
+		for bit := 0; bit < 8; bit++ {
+			if b&(1<<uint(bit)) != 0 {
+				ones++
+			}
+		}
+		if ones%2 == 0 {
+			t.Fatalf("byte %#x does not have odd parity", b)
+		}
+	}
+}
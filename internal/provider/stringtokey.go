@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/jcmturner/gokrb5/v8/crypto"
+	"github.com/jcmturner/gokrb5/v8/iana/etypeID"
+)
+
+// defaultSalt builds the MIT-style default salt for a principal: the realm
+// followed directly by the principal name, with no separator.
+func defaultSalt(realm, principal string) string {
+	return realm + principal
+}
+
+// s2kParams encodes an iteration count into the hex string format gokrb5's
+// etype implementations expect for their s2kparams argument.
+func s2kParams(iterations int64) string {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, uint32(iterations))
+	return hex.EncodeToString(b)
+}
+
+// deriveKey runs the appropriate RFC 3962 / RFC 8009 string-to-key KDF for
+// etype, returning the derived key bytes for a keytab entry built from a
+// password rather than raw key material.
+func deriveKey(etype int32, principal, realm, password, salt string, iterations int64) ([]byte, error) {
+	switch etype {
+	case etypeID.AES128_CTS_HMAC_SHA1_96, etypeID.AES256_CTS_HMAC_SHA1_96:
+		if salt == "" {
+			salt = defaultSalt(realm, principal)
+		}
+		if iterations == 0 {
+			iterations = 4096
+		}
+	case etypeID.AES128_CTS_HMAC_SHA256_128, etypeID.AES256_CTS_HMAC_SHA384_192:
+		if salt == "" {
+			salt = defaultSalt(realm, principal)
+		}
+		if iterations == 0 {
+			iterations = 32768
+		}
+	case etypeID.RC4_HMAC:
+		if salt != "" || iterations != 0 {
+			return nil, fmt.Errorf("salt and iterations are not supported for arcfour-hmac; the key is derived from the password alone")
+		}
+	case etypeID.DES3_CBC_SHA1_KD:
+		return nil, fmt.Errorf("password-based string-to-key is not supported for des3-cbc-sha1-kd")
+	default:
+		return nil, fmt.Errorf("password-based string-to-key is not supported for etype %d", etype)
+	}
+
+	e, err := crypto.GetEtype(etype)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.StringToKey(password, salt, s2kParams(iterations))
+}
+
+// etypeName returns the canonical name for an encryption type ID, i.e. MIT
+// krb5's primary name for it (arcfour-hmac for RC4) rather than one of the
+// aliases etypeID.ETypesByName also accepts.
+func etypeName(etype int32) string {
+	switch etype {
+	case etypeID.AES128_CTS_HMAC_SHA1_96:
+		return "aes128-cts-hmac-sha1-96"
+	case etypeID.AES256_CTS_HMAC_SHA1_96:
+		return "aes256-cts-hmac-sha1-96"
+	case etypeID.AES128_CTS_HMAC_SHA256_128:
+		return "aes128-cts-hmac-sha256-128"
+	case etypeID.AES256_CTS_HMAC_SHA384_192:
+		return "aes256-cts-hmac-sha384-192"
+	case etypeID.DES3_CBC_SHA1_KD:
+		return "des3-cbc-sha1-kd"
+	case etypeID.RC4_HMAC:
+		return "arcfour-hmac"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/jcmturner/gokrb5/v8/iana/etypeID"
+)
+
+// TestDeriveKey checks deriveKey against the published known-answer test
+// vectors for the string-to-key KDFs it wraps, rather than only round
+// tripping through AddEntry as the acceptance tests do, so that a change
+// which silently mis-derives keys (e.g. a wrong salt) is caught even though
+// it would produce a keytab that still parses and marshals correctly.
+func TestDeriveKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		etype      int32
+		password   string
+		salt       string
+		iterations int64
+		want       string
+	}{
+		// RFC 3962 Appendix B.
+		{
+			name:       "aes128-cts-hmac-sha1-96",
+			etype:      etypeID.AES128_CTS_HMAC_SHA1_96,
+			password:   "password",
+			salt:       "ATHENA.MIT.EDUraeburn",
+			iterations: 1200,
+			want:       "4c01cd46d632d01e6dbe230a01ed642a",
+		},
+		// RFC 8009 Appendix A. The salt is the random value from the vector
+		// (hex 10DF9DD783E5BC8ACEA1730E74355F61, taken as raw bytes) with
+		// "ATHENA.MIT.EDUraeburn" appended; deriveKey must pass this through
+		// unprefixed and let the etype implementation prepend its own
+		// "aes128-cts-hmac-sha256-128\x00" internally.
+		{
+			name:       "aes128-cts-hmac-sha256-128",
+			etype:      etypeID.AES128_CTS_HMAC_SHA256_128,
+			password:   "password",
+			salt:       "\x10\xDF\x9D\xD7\x83\xE5\xBC\x8A\xCE\xA1\x73\x0E\x74\x35\x5F\x61ATHENA.MIT.EDUraeburn",
+			iterations: 32768,
+			want:       "089bca48b105ea6ea77ca5d2f39dc5e7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := deriveKey(tt.etype, "", "", tt.password, tt.salt, tt.iterations)
+
+			if err != nil {
+				t.Fatal(err.Error())
+				return
+			}
+
+			want, err := hex.DecodeString(tt.want)
+
+			if err != nil {
+				t.Fatal(err.Error())
+				return
+			}
+
+			if hex.EncodeToString(got) != hex.EncodeToString(want) {
+				t.Fatalf("expected key %x, got %x", want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Package timevalidator provides validator.String implementations for time
+// formats not covered by terraform-plugin-framework-validators.
+package timevalidator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = rfc3339TimeValidator{}
+
+type rfc3339TimeValidator struct{}
+
+func (v rfc3339TimeValidator) Description(_ context.Context) string {
+	return "value must be a valid RFC3339 timestamp"
+}
+
+func (v rfc3339TimeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v rfc3339TimeValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid RFC3339 Timestamp",
+			fmt.Sprintf("%q is not a valid RFC3339 timestamp: %s", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
+
+// IsRFC3339Time returns a validator which ensures that any configured
+// attribute value is a valid RFC3339 timestamp. Null (unconfigured) and
+// unknown (known after apply) values are skipped.
+func IsRFC3339Time() validator.String {
+	return rfc3339TimeValidator{}
+}